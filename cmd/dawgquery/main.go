@@ -0,0 +1,128 @@
+// Command dawgquery inspects a DAWG the way golang.org/x/tools/cmd/digraph
+// inspects an import graph.
+//
+// Usage:
+//
+//	dawgquery <wordlist> <command> [args...]
+//
+// Commands:
+//
+//	succs <node>
+//	preds <node>
+//	forward <node...>
+//	reverse <node...>
+//	somepath <from> <to>
+//	allpaths <from> <to>
+//	sccs
+//	degree <node>
+//
+// <wordlist> is a newline-separated word list in the format read by
+// dawg.FromReader. Nodes are the indices printed by the commands
+// themselves; node 0 is always the root.
+package main
+
+import (
+  "fmt"
+  "os"
+  "strconv"
+  "strings"
+
+  "github.com/agiletechvn/golang-user-lib/dawg"
+  "github.com/agiletechvn/golang-user-lib/dawg/query"
+)
+
+func main() {
+  if len(os.Args) < 3 {
+    fmt.Fprintln(os.Stderr, "usage: dawgquery <wordlist> <command> [args...]")
+    os.Exit(2)
+  }
+
+  f, err := os.Open(os.Args[1])
+  if err != nil {
+    fatal(err)
+  }
+  d, err := dawg.FromReader(f)
+  f.Close()
+  if err != nil {
+    fatal(err)
+  }
+  g := query.New(d.Flatten())
+
+  cmd := os.Args[2]
+  args := os.Args[3:]
+
+  switch cmd {
+  case "succs":
+    requireArgs(cmd, args, 1)
+    printNodes(g.Succs(atoi(args[0])))
+  case "preds":
+    requireArgs(cmd, args, 1)
+    printNodes(g.Preds(atoi(args[0])))
+  case "forward":
+    printNodes(g.Forward(atoiAll(args)...))
+  case "reverse":
+    printNodes(g.Reverse(atoiAll(args)...))
+  case "somepath":
+    requireArgs(cmd, args, 2)
+    path, ok := g.SomePath(atoi(args[0]), atoi(args[1]))
+    if !ok {
+      fatal(fmt.Errorf("no path"))
+    }
+    fmt.Println(string(path))
+  case "allpaths":
+    requireArgs(cmd, args, 2)
+    for _, path := range g.AllPaths(atoi(args[0]), atoi(args[1])) {
+      fmt.Println(string(path))
+    }
+  case "sccs":
+    for _, comp := range g.SCCs() {
+      strs := make([]string, len(comp))
+      for i, n := range comp {
+        strs[i] = strconv.Itoa(n)
+      }
+      fmt.Println(strings.Join(strs, " "))
+    }
+  case "degree":
+    requireArgs(cmd, args, 1)
+    in, out := g.Degree(atoi(args[0]))
+    fmt.Printf("in=%d out=%d\n", in, out)
+  default:
+    fatal(fmt.Errorf("unknown command %q", cmd))
+  }
+}
+
+// requireArgs checks that args has at least n elements, exiting with a
+// usage error (like fatal) if cmd was not given enough of them.
+func requireArgs(cmd string, args []string, n int) {
+  if len(args) < n {
+    fmt.Fprintf(os.Stderr, "dawgquery: %s requires %d argument(s)\n", cmd, n)
+    os.Exit(2)
+  }
+}
+
+func atoi(s string) int {
+  n, err := strconv.Atoi(s)
+  if err != nil {
+    fatal(err)
+  }
+  return n
+}
+
+func atoiAll(args []string) []int {
+  ns := make([]int, len(args))
+  for i, a := range args {
+    ns[i] = atoi(a)
+  }
+  return ns
+}
+
+func printNodes(ns []int) {
+  for _, n := range ns {
+    fmt.Println(n)
+  }
+}
+
+func fatal(err error) {
+  fmt.Fprintln(os.Stderr, "dawgquery:", err)
+  os.Exit(1)
+}
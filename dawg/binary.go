@@ -0,0 +1,107 @@
+package dawg
+
+import (
+  "bytes"
+  "encoding/binary"
+  "fmt"
+  "io"
+)
+
+const binaryMagic = "DWG1"
+
+// binaryHeader precedes the bit-packed edge stream. It records the field
+// widths chosen for this graph so the loader can unpack edges without
+// guessing them.
+type binaryHeader struct {
+  NodeCount uint32
+  EdgeCount uint32
+  IndexBits uint8
+}
+
+func b2u(b bool) uint64 {
+  if b {
+    return 1
+  }
+  return 0
+}
+
+// WriteBinary writes d to w in a compact bit-packed format, modeled after
+// the encoding used by x/net/publicsuffix's table generator: every edge of
+// Flatten is packed into a fixed-width word whose fields (child index,
+// is-final, end-of-list, byte label) occupy only as many bits as this
+// graph's actual node and edge counts require. The result is a small
+// fraction of the size of the in-memory graph and can be read back with
+// Load/LoadBinary, or decoded on the fly with ReadOnlyDAWG without ever
+// reconstructing a *Node.
+func (d *DAWG) WriteBinary(w io.Writer) error {
+  edges := d.Flatten()
+  indexBits := bitsFor(len(edges))
+
+  if _, err := io.WriteString(w, binaryMagic); err != nil {
+    return err
+  }
+  hdr := binaryHeader{
+    NodeCount: uint32(d.NodeCount()),
+    EdgeCount: uint32(len(edges)),
+    IndexBits: uint8(indexBits),
+  }
+  if err := binary.Write(w, binary.BigEndian, hdr); err != nil {
+    return err
+  }
+
+  edgeBits := uint(8 + 1 + 1 + indexBits)
+  bw := newBitWriter()
+  for _, e := range edges {
+    v := uint64(e.B)
+    v = v<<1 | b2u(e.F)
+    v = v<<1 | b2u(e.EOL)
+    v = v<<uint(indexBits) | uint64(e.Index)
+    bw.writeBits(v, edgeBits)
+  }
+  _, err := w.Write(bw.bytes())
+  return err
+}
+
+// Load reads a DAWG previously written by WriteBinary from r and returns a
+// ReadOnlyDAWG that decodes edges on the fly.
+func Load(r io.Reader) (*ReadOnlyDAWG, error) {
+  data, err := io.ReadAll(r)
+  if err != nil {
+    return nil, err
+  }
+  return LoadBinary(data)
+}
+
+// LoadBinary parses a DAWG previously written by WriteBinary out of data.
+// data is not copied, so it may be backed by an mmap'd file: lookups read
+// directly out of it and Lookup/LookupPrefix never allocate a *Node.
+func LoadBinary(data []byte) (*ReadOnlyDAWG, error) {
+  const headerLen = 9 // uint32 + uint32 + uint8
+  if len(data) < len(binaryMagic)+headerLen {
+    return nil, fmt.Errorf("dawg: binary data too short")
+  }
+  if string(data[:len(binaryMagic)]) != binaryMagic {
+    return nil, fmt.Errorf("dawg: bad magic")
+  }
+  off := len(binaryMagic)
+
+  var hdr binaryHeader
+  if err := binary.Read(bytes.NewReader(data[off:off+headerLen]), binary.BigEndian, &hdr); err != nil {
+    return nil, err
+  }
+  off += headerLen
+
+  edgeBits := uint(8 + 1 + 1 + hdr.IndexBits)
+  needBits := uint64(hdr.EdgeCount) * uint64(edgeBits)
+  needBytes := (needBits + 7) / 8
+  if uint64(len(data)-off) < needBytes {
+    return nil, fmt.Errorf("dawg: binary data too short for %d edges", hdr.EdgeCount)
+  }
+
+  return &ReadOnlyDAWG{
+    data:      data[off:],
+    edgeCount: int(hdr.EdgeCount),
+    indexBits: uint(hdr.IndexBits),
+    edgeBits:  edgeBits,
+  }, nil
+}
@@ -0,0 +1,55 @@
+package dawg
+
+import (
+  "bytes"
+  "strings"
+  "testing"
+)
+
+func TestWriteBinaryRoundTrip(t *testing.T) {
+  words := []string{"cat", "cats", "dog", "do", "dogs"}
+  d, err := FromReader(strings.NewReader(strings.Join(words, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  var buf bytes.Buffer
+  if err := d.WriteBinary(&buf); err != nil {
+    t.Fatal(err)
+  }
+
+  ro, err := Load(&buf)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, w := range words {
+    if !ro.Lookup(w) {
+      t.Errorf("Lookup(%q) = false, want true", w)
+    }
+  }
+  for _, w := range []string{"ca", "catss", "do g"} {
+    if ro.Lookup(w) {
+      t.Errorf("Lookup(%q) = true, want false", w)
+    }
+  }
+}
+
+func TestLoadBinaryTruncated(t *testing.T) {
+  words := []string{"cat", "cats", "dog", "do", "dogs"}
+  d, err := FromReader(strings.NewReader(strings.Join(words, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  var buf bytes.Buffer
+  if err := d.WriteBinary(&buf); err != nil {
+    t.Fatal(err)
+  }
+
+  full := buf.Bytes()
+  truncated := full[:len(full)-1]
+  if _, err := LoadBinary(truncated); err == nil {
+    t.Fatal("LoadBinary on truncated data: got nil error, want an error")
+  }
+}
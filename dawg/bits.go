@@ -0,0 +1,77 @@
+package dawg
+
+// bitWriter accumulates values of arbitrary bit width into a byte slice,
+// most-significant-bit first. It backs the bit-packed binary encoding in
+// binary.go.
+type bitWriter struct {
+  buf   []byte
+  cur   byte
+  nbits uint
+}
+
+func newBitWriter() *bitWriter {
+  return &bitWriter{}
+}
+
+// writeBits appends the low n bits of v.
+func (bw *bitWriter) writeBits(v uint64, n uint) {
+  for n > 0 {
+    free := 8 - bw.nbits
+    take := n
+    if take > free {
+      take = free
+    }
+    shift := n - take
+    bits := byte((v >> shift) & ((1 << take) - 1))
+    bw.cur |= bits << (free - take)
+    bw.nbits += take
+    n -= take
+    if bw.nbits == 8 {
+      bw.buf = append(bw.buf, bw.cur)
+      bw.cur = 0
+      bw.nbits = 0
+    }
+  }
+}
+
+// bytes returns the packed bytes, padding the final partial byte with zero bits.
+func (bw *bitWriter) bytes() []byte {
+  if bw.nbits > 0 {
+    return append(bw.buf, bw.cur)
+  }
+  return bw.buf
+}
+
+// readBits reads the n bits (n <= 57) starting at bitOffset out of data,
+// most-significant-bit first.
+func readBits(data []byte, bitOffset uint64, n uint) uint64 {
+  var v uint64
+  for n > 0 {
+    byteIdx := bitOffset / 8
+    bitInByte := uint(bitOffset % 8)
+    free := 8 - bitInByte
+    take := n
+    if take > free {
+      take = free
+    }
+    shift := free - take
+    mask := byte((1 << take) - 1)
+    bits := (data[byteIdx] >> shift) & mask
+    v = v<<take | uint64(bits)
+    bitOffset += uint64(take)
+    n -= take
+  }
+  return v
+}
+
+// bitsFor returns the number of bits needed to represent every value in [0, n).
+func bitsFor(n int) int {
+  if n <= 1 {
+    return 1
+  }
+  width := 0
+  for m := n - 1; m > 0; m >>= 1 {
+    width++
+  }
+  return width
+}
@@ -119,6 +119,25 @@ func (d *DAWG) Insert(word string) {
 // Finish finishes the construction of the DAWG.
 func (d *DAWG) Finish() {
   d.minimize(0)
+  d.root.Count = countWords(&d.root, make(map[int]int))
+}
+
+// countWords computes, and memoizes by node ID, the number of accepted
+// words reachable from n (including n itself, if it is final).
+func countWords(n *Node, memo map[int]int) int {
+  if c, ok := memo[n.ID]; ok {
+    return c
+  }
+  count := 0
+  if n.F {
+    count = 1
+  }
+  for _, c := range n.C {
+    count += countWords(c, memo)
+  }
+  memo[n.ID] = count
+  n.Count = count
+  return count
 }
 
 // NodeCount returns the count of the nodes in the graph.
@@ -242,9 +261,10 @@ func (d *DAWG) Flatten() []ArrayNode {
       to = append(to, c.ID)
       hasChildren = append(hasChildren, len(c.C) > 0)
       result = append(result, ArrayNode{
-        B:   b,
-        F:   c.F,
-        EOL: j == len(keys)-1,
+        B:     b,
+        F:     c.F,
+        EOL:   j == len(keys)-1,
+        Count: c.Count,
       })
       index++
     }
@@ -0,0 +1,138 @@
+package dawg
+
+// Match is a word found by SearchEdit or SearchEditDamerau, together with
+// its edit distance from the search term.
+type Match struct {
+  Word string
+  Dist int
+}
+
+func minRow(row []int) int {
+  m := row[0]
+  for _, v := range row[1:] {
+    m = min(m, v)
+  }
+  return m
+}
+
+// SearchEdit returns every word in the graph within Levenshtein distance
+// maxDist of word.
+func (d *DAWG) SearchEdit(word string, maxDist int) []Match {
+  var matches []Match
+  d.SearchEditFunc(word, maxDist, func(m Match) bool {
+    matches = append(matches, m)
+    return true
+  })
+  return matches
+}
+
+// SearchEditFunc walks the graph depth-first, calling fn for every word
+// within Levenshtein distance maxDist of word, in the order the graph
+// stores them. It stops early if fn returns false.
+//
+// The walk carries a single row of the classical edit-distance DP table:
+// at the root, row is [0,1,2,...,len(word)]; at each edge labelled b, the
+// next row is derived from the previous one, and the branch is pruned as
+// soon as every entry in the new row exceeds maxDist.
+func (d *DAWG) SearchEditFunc(word string, maxDist int, fn func(Match) bool) {
+  row := make([]int, len(word)+1)
+  for i := range row {
+    row[i] = i
+  }
+
+  var buf []byte
+  var walk func(n *Node, prev []int) bool
+  walk = func(n *Node, prev []int) bool {
+    if n.F && prev[len(word)] <= maxDist {
+      if !fn(Match{Word: string(buf), Dist: prev[len(word)]}) {
+        return false
+      }
+    }
+
+    for _, b := range sortedKeys(n.C) {
+      next := make([]int, len(word)+1)
+      next[0] = prev[0] + 1
+      for j := 1; j <= len(word); j++ {
+        cost := 1
+        if word[j-1] == b {
+          cost = 0
+        }
+        next[j] = min(min(next[j-1]+1, prev[j]+1), prev[j-1]+cost)
+      }
+      if minRow(next) > maxDist {
+        continue
+      }
+
+      buf = append(buf, b)
+      if !walk(n.C[b], next) {
+        buf = buf[:len(buf)-1]
+        return false
+      }
+      buf = buf[:len(buf)-1]
+    }
+    return true
+  }
+
+  walk(&d.root, row)
+}
+
+// SearchEditDamerau returns every word in the graph within restricted
+// Damerau-Levenshtein (optimal string alignment) distance maxDist of word,
+// i.e. it additionally treats the transposition of two adjacent characters
+// as a single edit. A substring is not edited again after being
+// transposed.
+func (d *DAWG) SearchEditDamerau(word string, maxDist int) []Match {
+  var matches []Match
+  d.SearchEditDamerauFunc(word, maxDist, func(m Match) bool {
+    matches = append(matches, m)
+    return true
+  })
+  return matches
+}
+
+// SearchEditDamerauFunc is the streaming, transposition-aware counterpart
+// of SearchEditFunc.
+func (d *DAWG) SearchEditDamerauFunc(word string, maxDist int, fn func(Match) bool) {
+  row := make([]int, len(word)+1)
+  for i := range row {
+    row[i] = i
+  }
+
+  var buf []byte
+  var walk func(n *Node, prevByte byte, twoBack, prev []int) bool
+  walk = func(n *Node, prevByte byte, twoBack, prev []int) bool {
+    if n.F && prev[len(word)] <= maxDist {
+      if !fn(Match{Word: string(buf), Dist: prev[len(word)]}) {
+        return false
+      }
+    }
+
+    for _, b := range sortedKeys(n.C) {
+      next := make([]int, len(word)+1)
+      next[0] = prev[0] + 1
+      for j := 1; j <= len(word); j++ {
+        cost := 1
+        if word[j-1] == b {
+          cost = 0
+        }
+        next[j] = min(min(next[j-1]+1, prev[j]+1), prev[j-1]+cost)
+        if twoBack != nil && j >= 2 && b == word[j-2] && prevByte == word[j-1] {
+          next[j] = min(next[j], twoBack[j-2]+1)
+        }
+      }
+      if minRow(next) > maxDist {
+        continue
+      }
+
+      buf = append(buf, b)
+      if !walk(n.C[b], b, prev, next) {
+        buf = buf[:len(buf)-1]
+        return false
+      }
+      buf = buf[:len(buf)-1]
+    }
+    return true
+  }
+
+  walk(&d.root, 0, nil, row)
+}
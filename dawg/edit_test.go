@@ -0,0 +1,167 @@
+package dawg
+
+import (
+  "sort"
+  "strings"
+  "testing"
+)
+
+// levenshtein is a brute-force reference implementation used to check
+// SearchEdit against.
+func levenshtein(a, b string) int {
+  d := make([][]int, len(a)+1)
+  for i := range d {
+    d[i] = make([]int, len(b)+1)
+    d[i][0] = i
+  }
+  for j := range d[0] {
+    d[0][j] = j
+  }
+  for i := 1; i <= len(a); i++ {
+    for j := 1; j <= len(b); j++ {
+      cost := 1
+      if a[i-1] == b[j-1] {
+        cost = 0
+      }
+      d[i][j] = min(min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+    }
+  }
+  return d[len(a)][len(b)]
+}
+
+// osaDistance is a brute-force reference implementation of the restricted
+// edit distance (Levenshtein plus adjacent-transposition) SearchEditDamerau
+// is meant to compute.
+func osaDistance(a, b string) int {
+  d := make([][]int, len(a)+1)
+  for i := range d {
+    d[i] = make([]int, len(b)+1)
+    d[i][0] = i
+  }
+  for j := range d[0] {
+    d[0][j] = j
+  }
+  for i := 1; i <= len(a); i++ {
+    for j := 1; j <= len(b); j++ {
+      cost := 1
+      if a[i-1] == b[j-1] {
+        cost = 0
+      }
+      d[i][j] = min(min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+      if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+        d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+      }
+    }
+  }
+  return d[len(a)][len(b)]
+}
+
+func wordsWithin(dict []string, target string, maxDist int, dist func(a, b string) int) map[string]int {
+  want := make(map[string]int)
+  for _, w := range dict {
+    if dd := dist(w, target); dd <= maxDist {
+      want[w] = dd
+    }
+  }
+  return want
+}
+
+func matchMap(matches []Match) map[string]int {
+  got := make(map[string]int, len(matches))
+  for _, m := range matches {
+    got[m.Word] = m.Dist
+  }
+  return got
+}
+
+var editDict = []string{
+  "cat", "cats", "cot", "cart", "car", "care", "dog", "dogs", "do",
+  "cast", "act", "tac", "cta", "ca", "caat",
+}
+
+func TestSearchEditMatchesLevenshtein(t *testing.T) {
+  dict := append([]string(nil), editDict...)
+  sort.Strings(dict)
+  d, err := FromReader(strings.NewReader(strings.Join(dict, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, tc := range []struct {
+    word    string
+    maxDist int
+  }{
+    {"cat", 0},
+    {"cat", 1},
+    {"cat", 2},
+    {"dog", 1},
+    {"xyz", 2},
+  } {
+    want := wordsWithin(dict, tc.word, tc.maxDist, levenshtein)
+    got := matchMap(d.SearchEdit(tc.word, tc.maxDist))
+    if len(got) != len(want) {
+      t.Errorf("SearchEdit(%q, %d) = %v, want %v", tc.word, tc.maxDist, got, want)
+      continue
+    }
+    for w, dd := range want {
+      if got[w] != dd {
+        t.Errorf("SearchEdit(%q, %d)[%q] = %d, want %d", tc.word, tc.maxDist, w, got[w], dd)
+      }
+    }
+  }
+}
+
+func TestSearchEditDamerauMatchesOSA(t *testing.T) {
+  dict := append([]string(nil), editDict...)
+  sort.Strings(dict)
+  d, err := FromReader(strings.NewReader(strings.Join(dict, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, tc := range []struct {
+    word    string
+    maxDist int
+  }{
+    {"cat", 0},
+    {"cat", 1},
+    {"cat", 2},
+    {"atc", 1}, // transposition of "act" -> "atc" is a single Damerau edit
+    {"tac", 1},
+    {"dgo", 1}, // transposition of "dog"
+  } {
+    want := wordsWithin(dict, tc.word, tc.maxDist, osaDistance)
+    got := matchMap(d.SearchEditDamerau(tc.word, tc.maxDist))
+    if len(got) != len(want) {
+      t.Errorf("SearchEditDamerau(%q, %d) = %v, want %v", tc.word, tc.maxDist, got, want)
+      continue
+    }
+    for w, dd := range want {
+      if got[w] != dd {
+        t.Errorf("SearchEditDamerau(%q, %d)[%q] = %d, want %d", tc.word, tc.maxDist, w, got[w], dd)
+      }
+    }
+  }
+}
+
+func TestSearchEditDamerauTranspositionCheaperThanLevenshtein(t *testing.T) {
+  // "cat" -> "cta" swaps the last two characters: a single Damerau edit,
+  // but plain Levenshtein needs two substitutions.
+  if got := osaDistance("cat", "cta"); got != 1 {
+    t.Fatalf("osaDistance(cat, cta) = %d, want 1", got)
+  }
+  if got := levenshtein("cat", "cta"); got != 2 {
+    t.Fatalf("levenshtein(cat, cta) = %d, want 2", got)
+  }
+
+  d, err := FromReader(strings.NewReader("cta"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if matches := d.SearchEditDamerau("cat", 1); len(matches) != 1 || matches[0].Dist != 1 {
+    t.Fatalf("SearchEditDamerau(cat, 1) = %v, want [{cta 1}]", matches)
+  }
+  if matches := d.SearchEdit("cat", 1); len(matches) != 0 {
+    t.Fatalf("SearchEdit(cat, 1) = %v, want no matches", matches)
+  }
+}
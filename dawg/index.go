@@ -0,0 +1,73 @@
+package dawg
+
+// Count returns the total number of accepted words in the graph.
+// Count must be called after Finish.
+func (d *DAWG) Count() int {
+  return d.root.Count
+}
+
+// IndexOf returns the dense, zero-based rank of word among all accepted
+// words sorted alphabetically, along with whether word is in the graph.
+// IndexOf must be called after Finish.
+func (d *DAWG) IndexOf(word string) (int, bool) {
+  curr := &d.root
+  index := 0
+
+  for i := 0; i < len(word); i++ {
+    b := word[i]
+    if curr.F {
+      index++
+    }
+    for _, k := range sortedKeys(curr.C) {
+      if k >= b {
+        break
+      }
+      index += curr.C[k].Count
+    }
+
+    next, ok := curr.C[b]
+    if !ok {
+      return 0, false
+    }
+    curr = next
+  }
+
+  if !curr.F {
+    return 0, false
+  }
+  return index, true
+}
+
+// WordAt returns the word whose IndexOf is i, along with whether i is a
+// valid index. WordAt must be called after Finish.
+func (d *DAWG) WordAt(i int) (string, bool) {
+  if i < 0 || i >= d.root.Count {
+    return "", false
+  }
+
+  curr := &d.root
+  var buf []byte
+  for {
+    if curr.F {
+      if i == 0 {
+        return string(buf), true
+      }
+      i--
+    }
+
+    found := false
+    for _, b := range sortedKeys(curr.C) {
+      c := curr.C[b]
+      if i < c.Count {
+        buf = append(buf, b)
+        curr = c
+        found = true
+        break
+      }
+      i -= c.Count
+    }
+    if !found {
+      return "", false
+    }
+  }
+}
@@ -0,0 +1,65 @@
+package dawg
+
+import (
+  "sort"
+  "strings"
+  "testing"
+)
+
+func TestIndexOfWordAtRoundTrip(t *testing.T) {
+  words := []string{
+    "a", "and", "ant", "ante", "anteater", "antelope", "any", "banana",
+    "band", "bandana", "bandit", "can", "candy", "cat", "catalog",
+  }
+  sorted := append([]string(nil), words...)
+  sort.Strings(sorted)
+
+  d, err := FromReader(strings.NewReader(strings.Join(words, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if got, want := d.Count(), len(sorted); got != want {
+    t.Fatalf("Count() = %d, want %d", got, want)
+  }
+
+  for wantIdx, w := range sorted {
+    idx, ok := d.IndexOf(w)
+    if !ok {
+      t.Errorf("IndexOf(%q) = (_, false), want (%d, true)", w, wantIdx)
+      continue
+    }
+    if idx != wantIdx {
+      t.Errorf("IndexOf(%q) = %d, want %d", w, idx, wantIdx)
+    }
+
+    got, ok := d.WordAt(wantIdx)
+    if !ok || got != w {
+      t.Errorf("WordAt(%d) = (%q, %v), want (%q, true)", wantIdx, got, ok, w)
+    }
+  }
+}
+
+func TestIndexOfMissingWord(t *testing.T) {
+  d, err := FromReader(strings.NewReader("cat\ncats\ndog"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, w := range []string{"ca", "catz", "do", "doge"} {
+    if _, ok := d.IndexOf(w); ok {
+      t.Errorf("IndexOf(%q) = (_, true), want (_, false)", w)
+    }
+  }
+}
+
+func TestWordAtOutOfRange(t *testing.T) {
+  d, err := FromReader(strings.NewReader("cat\ncats\ndog"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, i := range []int{-1, d.Count(), d.Count() + 1} {
+    if _, ok := d.WordAt(i); ok {
+      t.Errorf("WordAt(%d) = (_, true), want (_, false)", i)
+    }
+  }
+}
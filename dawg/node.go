@@ -8,9 +8,10 @@ import (
 
 // Node is a DAWG node.
 type Node struct {
-  ID int
-  F  bool           // final?
-  C  map[byte]*Node // children
+  ID    int
+  F     bool           // final?
+  C     map[byte]*Node // children
+  Count int            // number of accepted words reachable from this node, set by Finish
 }
 
 // ArrayNode is a node in a flattened DAWG representation.
@@ -19,6 +20,7 @@ type ArrayNode struct {
   B     byte // byte
   F     bool // final?
   EOL   bool // end of list?
+  Count int  // number of accepted words reachable from the node this edge leads to
 }
 
 func numDigits(n int) int {
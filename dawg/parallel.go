@@ -0,0 +1,386 @@
+package dawg
+
+import (
+  "bufio"
+  "container/heap"
+  "os"
+  "runtime"
+  "sort"
+  "sync"
+)
+
+// ParallelBuilderOptions configures a ParallelBuilder.
+type ParallelBuilderOptions struct {
+  // Shards is the number of independent sub-DAWGs built concurrently.
+  // Defaults to runtime.NumCPU().
+  Shards int
+
+  // ShardKeyLen is the number of leading bytes of each word used to pick
+  // its shard. Defaults to 1.
+  ShardKeyLen int
+
+  // MemoryWatermark is the number of buffered bytes a shard accumulates
+  // before sorting and spilling them to a temp file. 0 (the default)
+  // disables external-memory mode and keeps every shard entirely in RAM.
+  MemoryWatermark int
+
+  // TempDir is the directory spill files are created in. Defaults to
+  // os.TempDir().
+  TempDir string
+}
+
+// ParallelBuilder builds a DAWG from an unsorted, arbitrarily large word
+// list. Unlike Insert, Add accepts words in any order and may be called
+// concurrently: words are sharded by their first ShardKeyLen bytes across
+// Shards goroutines, each of which sorts and minimizes its own shard
+// independently. Build then merges the shards under a common root,
+// re-running node minimization across the union of their nodes.
+type ParallelBuilder struct {
+  opts   ParallelBuilderOptions
+  shards []*shard
+  wg     sync.WaitGroup
+}
+
+type shard struct {
+  opts     ParallelBuilderOptions
+  in       chan string
+  done     chan struct{}
+  buf      []string
+  bufBytes int
+  runs     []string // paths of sorted runs spilled to disk
+  err      error    // first error absorb/spill hit; surfaced by sortedEach
+}
+
+// NewParallelBuilder starts the shard workers for a new ParallelBuilder.
+func NewParallelBuilder(opts ParallelBuilderOptions) *ParallelBuilder {
+  if opts.Shards <= 0 {
+    opts.Shards = runtime.NumCPU()
+  }
+  if opts.ShardKeyLen <= 0 {
+    opts.ShardKeyLen = 1
+  }
+  if opts.TempDir == "" {
+    opts.TempDir = os.TempDir()
+  }
+
+  pb := &ParallelBuilder{opts: opts, shards: make([]*shard, opts.Shards)}
+  for i := range pb.shards {
+    s := &shard{opts: opts, in: make(chan string, 64), done: make(chan struct{})}
+    pb.shards[i] = s
+    pb.wg.Add(1)
+    go func() {
+      defer pb.wg.Done()
+      for w := range s.in {
+        s.absorb(w)
+      }
+      close(s.done)
+    }()
+  }
+  return pb
+}
+
+// Add queues word for insertion. It may be called concurrently from
+// multiple goroutines and words may arrive in any order.
+func (pb *ParallelBuilder) Add(word string) {
+  pb.shards[shardFor(word, pb.opts.ShardKeyLen, len(pb.shards))].in <- word
+}
+
+// shardFor hashes the first keyLen bytes of word (FNV-1a) to pick a shard.
+func shardFor(word string, keyLen, n int) int {
+  var h uint32 = 2166136261
+  for i := 0; i < keyLen && i < len(word); i++ {
+    h ^= uint32(word[i])
+    h *= 16777619
+  }
+  return int(h % uint32(n))
+}
+
+func (s *shard) absorb(word string) {
+  s.buf = append(s.buf, word)
+  s.bufBytes += len(word)
+  if s.opts.MemoryWatermark > 0 && s.bufBytes >= s.opts.MemoryWatermark {
+    if err := s.spill(); err != nil && s.err == nil {
+      s.err = err
+    }
+  }
+}
+
+// spill sorts the shard's current buffer and appends it to disk as a new
+// run, freeing the buffer. On any error (temp file creation, write, or
+// flush) it returns the error without clearing the buffer; the caller
+// records it on s.err so it surfaces through sortedEach instead of
+// silently dropping the spilled words.
+func (s *shard) spill() error {
+  sort.Strings(s.buf)
+
+  f, err := os.CreateTemp(s.opts.TempDir, "dawg-shard-*.run")
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+
+  w := bufio.NewWriter(f)
+  for _, word := range s.buf {
+    if _, err := w.WriteString(word); err != nil {
+      return err
+    }
+    if err := w.WriteByte('\n'); err != nil {
+      return err
+    }
+  }
+  if err := w.Flush(); err != nil {
+    return err
+  }
+
+  s.runs = append(s.runs, f.Name())
+  s.buf = s.buf[:0]
+  s.bufBytes = 0
+  return nil
+}
+
+type mergeItem struct {
+  word string
+  src  int
+}
+
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i].word < h[j].word }
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() interface{} {
+  old := *h
+  n := len(old)
+  it := old[n-1]
+  *h = old[:n-1]
+  return it
+}
+
+// sortedEach calls fn with every word contributed to the shard, in sorted
+// order, k-way merging its spilled runs (if any) with its in-memory
+// remainder so the shard never needs to hold more than one run's worth of
+// words in RAM at once. Duplicate words are delivered only once. It
+// returns any error recorded while spilling, or any error the scanner
+// over a spilled run hits (including a line exceeding bufio.Scanner's
+// token limit), rather than treating a failed Scan as a clean end of run.
+func (s *shard) sortedEach(fn func(word string) error) error {
+  if s.err != nil {
+    return s.err
+  }
+  sort.Strings(s.buf)
+
+  var files []*os.File
+  var scanners []*bufio.Scanner
+  defer func() {
+    for _, f := range files {
+      f.Close()
+      os.Remove(f.Name())
+    }
+  }()
+  for _, path := range s.runs {
+    f, err := os.Open(path)
+    if err != nil {
+      return err
+    }
+    files = append(files, f)
+    scanners = append(scanners, bufio.NewScanner(f))
+  }
+
+  var scanErr error
+  memIdx := 0
+  next := func(src int) (string, bool) {
+    if src < 0 {
+      if memIdx < len(s.buf) {
+        w := s.buf[memIdx]
+        memIdx++
+        return w, true
+      }
+      return "", false
+    }
+    if scanners[src].Scan() {
+      return scanners[src].Text(), true
+    }
+    if err := scanners[src].Err(); err != nil {
+      scanErr = err
+    }
+    return "", false
+  }
+
+  h := make(mergeHeap, 0, len(scanners)+1)
+  if w, ok := next(-1); ok {
+    heap.Push(&h, mergeItem{w, -1})
+  }
+  for i := range scanners {
+    if w, ok := next(i); ok {
+      heap.Push(&h, mergeItem{w, i})
+    }
+  }
+  if scanErr != nil {
+    return scanErr
+  }
+
+  var prev string
+  first := true
+  for h.Len() > 0 {
+    top := heap.Pop(&h).(mergeItem)
+    if first || top.word != prev {
+      if err := fn(top.word); err != nil {
+        return err
+      }
+      prev, first = top.word, false
+    }
+    if w, ok := next(top.src); ok {
+      heap.Push(&h, mergeItem{w, top.src})
+    } else if scanErr != nil {
+      return scanErr
+    }
+  }
+  return nil
+}
+
+// offsetIDs adds offset to the ID of every node reachable from n, visiting
+// each node exactly once. Nodes are tracked by pointer rather than by ID:
+// a shared node (the same *Node reached through two different parents, as
+// minimization routinely produces) would otherwise be offset a second time
+// once its ID had already been mutated by the first visit.
+func offsetIDs(n *Node, offset int, seen map[*Node]bool) {
+  if seen[n] {
+    return
+  }
+  seen[n] = true
+  n.ID += offset
+  for _, c := range n.C {
+    offsetIDs(c, offset, seen)
+  }
+}
+
+// mergeNodes unions two nodes that occupy the same edge, recursively
+// unioning any of their children that collide. It allocates a fresh node
+// for every collision, assigning it the next id from nextID.
+func mergeNodes(a, b *Node, nextID *int) *Node {
+  *nextID++
+  n := &Node{ID: *nextID, F: a.F || b.F, C: make(map[byte]*Node, len(a.C)+len(b.C))}
+  for k, v := range a.C {
+    n.C[k] = v
+  }
+  for k, v := range b.C {
+    if existing, ok := n.C[k]; ok {
+      n.C[k] = mergeNodes(existing, v, nextID)
+    } else {
+      n.C[k] = v
+    }
+  }
+  return n
+}
+
+// canonicalize re-minimizes a merged tree bottom-up, exactly like
+// (*DAWG).minimize but over an arbitrary node set rather than the
+// unchecked stack: nodes are visited in post-order so that, by the time a
+// node's own key() is computed, its children already point at their
+// canonical representatives.
+func canonicalize(children map[byte]*Node) (map[byte]*Node, map[string]*Node) {
+  visited := make(map[int]bool)
+  var order []*Node
+  var visit func(n *Node)
+  visit = func(n *Node) {
+    if visited[n.ID] {
+      return
+    }
+    visited[n.ID] = true
+    for _, b := range sortedKeys(n.C) {
+      visit(n.C[b])
+    }
+    order = append(order, n)
+  }
+  for _, b := range sortedKeys(children) {
+    visit(children[b])
+  }
+
+  canon := make(map[int]*Node)
+  minimized := make(map[string]*Node)
+  for _, n := range order {
+    for b, c := range n.C {
+      if m, ok := canon[c.ID]; ok {
+        n.C[b] = m
+      }
+    }
+    k := n.key()
+    if m, ok := minimized[k]; ok {
+      canon[n.ID] = m
+    } else {
+      minimized[k] = n
+      canon[n.ID] = n
+    }
+  }
+
+  result := make(map[byte]*Node, len(children))
+  for b, c := range children {
+    if m, ok := canon[c.ID]; ok {
+      result[b] = m
+    } else {
+      result[b] = c
+    }
+  }
+  return result, minimized
+}
+
+// Build waits for all shards to finish absorbing words, builds each
+// shard's sub-DAWG concurrently (sorting and minimizing each shard is the
+// expensive part, and shards are fully independent of one another), then
+// merges them under a common root on the caller's goroutine.
+func (pb *ParallelBuilder) Build() (*DAWG, error) {
+  for _, s := range pb.shards {
+    close(s.in)
+  }
+  pb.wg.Wait()
+
+  subs := make([]*DAWG, len(pb.shards))
+  errs := make([]error, len(pb.shards))
+  var buildWG sync.WaitGroup
+  buildWG.Add(len(pb.shards))
+  for i, s := range pb.shards {
+    go func(i int, s *shard) {
+      defer buildWG.Done()
+      var sub DAWG
+      if err := s.sortedEach(func(word string) error {
+        sub.Insert(word)
+        return nil
+      }); err != nil {
+        errs[i] = err
+        return
+      }
+      sub.Finish()
+      subs[i] = &sub
+    }(i, s)
+  }
+  buildWG.Wait()
+
+  merged := make(map[byte]*Node)
+  nextID := 0
+  for i, sub := range subs {
+    if errs[i] != nil {
+      return nil, errs[i]
+    }
+
+    offsetIDs(&sub.root, nextID, make(map[*Node]bool))
+    nextID += sub.seq
+
+    for b, c := range sub.root.C {
+      if existing, ok := merged[b]; ok {
+        merged[b] = mergeNodes(existing, c, &nextID)
+      } else {
+        merged[b] = c
+      }
+    }
+  }
+
+  canon, minimized := canonicalize(merged)
+
+  var d DAWG
+  d.root.C = canon
+  d.seq = nextID
+  d.minimized = minimized
+  d.root.Count = countWords(&d.root, make(map[int]int))
+  return &d, nil
+}
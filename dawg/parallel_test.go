@@ -0,0 +1,95 @@
+package dawg
+
+import (
+  "sort"
+  "strings"
+  "testing"
+)
+
+func TestParallelBuilderMatchesSerialBuild(t *testing.T) {
+  words := []string{
+    "cat", "cats", "catalog", "car", "care", "dog", "dogs", "do",
+    "apple", "app", "apply", "banana", "band", "bandana", "zebra", "zoo",
+  }
+
+  pb := NewParallelBuilder(ParallelBuilderOptions{Shards: 4, ShardKeyLen: 1})
+  for _, w := range words {
+    pb.Add(w)
+  }
+  d, err := pb.Build()
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, w := range words {
+    if !d.Lookup(w) {
+      t.Errorf("Lookup(%q) = false, want true", w)
+    }
+  }
+  for _, w := range []string{"ca", "catz", "appl", "doge"} {
+    if d.Lookup(w) {
+      t.Errorf("Lookup(%q) = true, want false", w)
+    }
+  }
+
+  if got, want := d.Root().Count, len(words); got != want {
+    t.Errorf("word count = %d, want %d", got, want)
+  }
+
+  serial, err := FromReader(strings.NewReader(strings.Join(words, "\n")))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got, want := d.NodeCount(), serial.NodeCount(); got != want {
+    t.Errorf("NodeCount() = %d, want %d (serial build)", got, want)
+  }
+  if got, want := d.EdgeCount(), serial.EdgeCount(); got != want {
+    t.Errorf("EdgeCount() = %d, want %d (serial build)", got, want)
+  }
+}
+
+func TestParallelBuilderExternalMemory(t *testing.T) {
+  words := make([]string, 0, 200)
+  for i := 0; i < 200; i++ {
+    words = append(words, strings.Repeat("a", i%5+1)+string(rune('a'+i%26)))
+  }
+  sort.Strings(words)
+
+  pb := NewParallelBuilder(ParallelBuilderOptions{
+    Shards:          3,
+    MemoryWatermark: 32, // force repeated spills well before all words arrive
+  })
+  seen := make(map[string]bool)
+  for _, w := range words {
+    if seen[w] {
+      continue
+    }
+    seen[w] = true
+    pb.Add(w)
+  }
+  d, err := pb.Build()
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for w := range seen {
+    if !d.Lookup(w) {
+      t.Errorf("Lookup(%q) = false, want true", w)
+    }
+  }
+  if got, want := d.Root().Count, len(seen); got != want {
+    t.Errorf("word count = %d, want %d", got, want)
+  }
+}
+
+func TestParallelBuilderSpillErrorPropagates(t *testing.T) {
+  pb := NewParallelBuilder(ParallelBuilderOptions{
+    Shards:          1,
+    MemoryWatermark: 1, // spill on the very first word
+    TempDir:         "/nonexistent-dawg-spill-dir",
+  })
+  pb.Add("cat")
+  if _, err := pb.Build(); err == nil {
+    t.Fatal("Build with an unwritable TempDir: got nil error, want an error")
+  }
+}
@@ -0,0 +1,219 @@
+// Package query implements graph query primitives over a flattened DAWG,
+// modelled on the commands exposed by golang.org/x/tools/cmd/digraph. It
+// lets callers inspect and debug large dictionaries the way digraph
+// inspects import graphs, without reconstructing *dawg.Node.
+package query
+
+import (
+  "sort"
+
+  "github.com/agiletechvn/golang-user-lib/dawg"
+)
+
+// Graph is a read-only view over the output of (*dawg.DAWG).Flatten.
+// Nodes are identified by the index into that slice at which their outgoing
+// edges begin; 0 is always the root.
+type Graph struct {
+  edges  []dawg.ArrayNode
+  starts []int
+  preds  map[int][]int
+}
+
+// New builds a Graph over the flattened edges of a DAWG.
+func New(edges []dawg.ArrayNode) *Graph {
+  starts := make([]int, len(edges))
+  start := 0
+  for i, e := range edges {
+    starts[i] = start
+    if e.EOL {
+      start = i + 1
+    }
+  }
+
+  preds := make(map[int][]int)
+  for i, e := range edges {
+    if e.Index != 0 {
+      preds[e.Index] = append(preds[e.Index], starts[i])
+    }
+  }
+
+  return &Graph{edges: edges, starts: starts, preds: preds}
+}
+
+// Succs returns the nodes reachable in one step from node.
+func (g *Graph) Succs(node int) []int {
+  var out []int
+  for i := node; i < len(g.edges); i++ {
+    e := g.edges[i]
+    if e.Index != 0 {
+      out = append(out, e.Index)
+    }
+    if e.EOL {
+      break
+    }
+  }
+  return out
+}
+
+// Preds returns the nodes with an edge leading to node.
+func (g *Graph) Preds(node int) []int {
+  return g.preds[node]
+}
+
+// Forward returns the set of nodes transitively reachable from seeds,
+// including the seeds themselves.
+func (g *Graph) Forward(seeds ...int) []int {
+  return g.reachable(seeds, g.Succs)
+}
+
+// Reverse returns the set of nodes that can transitively reach seeds,
+// including the seeds themselves.
+func (g *Graph) Reverse(seeds ...int) []int {
+  return g.reachable(seeds, g.Preds)
+}
+
+func (g *Graph) reachable(seeds []int, next func(int) []int) []int {
+  seen := make(map[int]bool)
+  var queue []int
+  for _, s := range seeds {
+    if !seen[s] {
+      seen[s] = true
+      queue = append(queue, s)
+    }
+  }
+
+  var order []int
+  for len(queue) > 0 {
+    n := queue[0]
+    queue = queue[1:]
+    order = append(order, n)
+    for _, s := range next(n) {
+      if !seen[s] {
+        seen[s] = true
+        queue = append(queue, s)
+      }
+    }
+  }
+  return order
+}
+
+// SomePath returns the labels along one path from "from" to "to", read
+// together as a word fragment, and whether such a path exists.
+func (g *Graph) SomePath(from, to int) ([]byte, bool) {
+  if from == to {
+    return nil, true
+  }
+
+  type edge struct {
+    node  int
+    label byte
+  }
+  seen := map[int]bool{from: true}
+  parent := make(map[int]edge)
+  queue := []int{from}
+
+  for len(queue) > 0 {
+    n := queue[0]
+    queue = queue[1:]
+    for i := n; i < len(g.edges); i++ {
+      e := g.edges[i]
+      if e.Index != 0 && !seen[e.Index] {
+        seen[e.Index] = true
+        parent[e.Index] = edge{n, e.B}
+        if e.Index == to {
+          var labels []byte
+          for cur := to; cur != from; {
+            p := parent[cur]
+            labels = append([]byte{p.label}, labels...)
+            cur = p.node
+          }
+          return labels, true
+        }
+        queue = append(queue, e.Index)
+      }
+      if e.EOL {
+        break
+      }
+    }
+  }
+  return nil, false
+}
+
+// AllPaths returns the labels along every path from "from" to "to", each
+// read together as a word fragment.
+func (g *Graph) AllPaths(from, to int) [][]byte {
+  var results [][]byte
+  var path []byte
+
+  var visit func(n int)
+  visit = func(n int) {
+    if n == to {
+      cp := make([]byte, len(path))
+      copy(cp, path)
+      results = append(results, cp)
+      return
+    }
+    for i := n; i < len(g.edges); i++ {
+      e := g.edges[i]
+      if e.Index != 0 {
+        path = append(path, e.B)
+        visit(e.Index)
+        path = path[:len(path)-1]
+      }
+      if e.EOL {
+        break
+      }
+    }
+  }
+  visit(from)
+  return results
+}
+
+// nodeIDs returns every distinct node id appearing in the graph.
+func (g *Graph) nodeIDs() []int {
+  seen := map[int]bool{0: true}
+  ids := []int{0}
+  for _, s := range g.starts {
+    if !seen[s] {
+      seen[s] = true
+      ids = append(ids, s)
+    }
+  }
+  sort.Ints(ids)
+  return ids
+}
+
+// SCCs returns the strongly connected components of the graph. A flattened
+// DAWG is a DAG, so every component is trivially a singleton; SCCs exists
+// mainly as a validator that the flattened edges really do form one.
+func (g *Graph) SCCs() [][]int {
+  assigned := make(map[int]bool)
+  var comps [][]int
+
+  for _, n := range g.nodeIDs() {
+    if assigned[n] {
+      continue
+    }
+    reaches := make(map[int]bool)
+    for _, m := range g.Reverse(n) {
+      reaches[m] = true
+    }
+    var comp []int
+    for _, m := range g.Forward(n) {
+      if reaches[m] {
+        comp = append(comp, m)
+      }
+    }
+    sort.Ints(comp)
+    for _, m := range comp {
+      assigned[m] = true
+    }
+    comps = append(comps, comp)
+  }
+  return comps
+}
+
+// Degree returns the in-degree and out-degree of node.
+func (g *Graph) Degree(node int) (in, out int) {
+  return len(g.preds[node]), len(g.Succs(node))
+}
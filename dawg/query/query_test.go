@@ -0,0 +1,126 @@
+package query
+
+import (
+  "reflect"
+  "sort"
+  "testing"
+
+  "github.com/agiletechvn/golang-user-lib/dawg"
+)
+
+// fixedEdges is a small hand-built flattened graph, independent of
+// (*dawg.DAWG).Flatten, with a known topology:
+//
+//	0 --a--> 2 --x--> (leaf)
+//	0 --b--> 3 --y--> (leaf)
+//	3 --z--> 2
+//
+// Node 2 has two predecessors (0 and 3), exercising the reverse-edge
+// index; node 3's group has an edge ('y') that does not lead anywhere,
+// exercising the "Index == 0 means no child" sentinel.
+var fixedEdges = []dawg.ArrayNode{
+  {B: 'a', F: false, EOL: false, Index: 2}, // 0
+  {B: 'b', F: false, EOL: true, Index: 3},  // 1
+  {B: 'x', F: true, EOL: true, Index: 0},   // 2
+  {B: 'y', F: true, EOL: false, Index: 0},  // 3
+  {B: 'z', F: true, EOL: true, Index: 2},   // 4
+}
+
+func sortedInts(xs []int) []int {
+  cp := append([]int(nil), xs...)
+  sort.Ints(cp)
+  return cp
+}
+
+func TestGraphSuccsPreds(t *testing.T) {
+  g := New(fixedEdges)
+
+  if got, want := sortedInts(g.Succs(0)), []int{2, 3}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Succs(0) = %v, want %v", got, want)
+  }
+  if got := g.Succs(2); len(got) != 0 {
+    t.Errorf("Succs(2) = %v, want empty", got)
+  }
+  if got, want := g.Succs(3), []int{2}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Succs(3) = %v, want %v", got, want)
+  }
+
+  if got, want := sortedInts(g.Preds(2)), []int{0, 3}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Preds(2) = %v, want %v", got, want)
+  }
+  if got, want := g.Preds(3), []int{0}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Preds(3) = %v, want %v", got, want)
+  }
+  if got := g.Preds(0); len(got) != 0 {
+    t.Errorf("Preds(0) = %v, want empty", got)
+  }
+}
+
+func TestGraphDegree(t *testing.T) {
+  g := New(fixedEdges)
+
+  for _, tc := range []struct {
+    node    int
+    in, out int
+  }{
+    {0, 0, 2},
+    {2, 2, 0},
+    {3, 1, 1},
+  } {
+    in, out := g.Degree(tc.node)
+    if in != tc.in || out != tc.out {
+      t.Errorf("Degree(%d) = (%d, %d), want (%d, %d)", tc.node, in, out, tc.in, tc.out)
+    }
+  }
+}
+
+func TestGraphSomePath(t *testing.T) {
+  g := New(fixedEdges)
+
+  for _, tc := range []struct {
+    from, to int
+    want     string
+    ok       bool
+  }{
+    {0, 2, "a", true},
+    {0, 3, "b", true},
+    {3, 2, "z", true},
+    {0, 0, "", true},
+  } {
+    got, ok := g.SomePath(tc.from, tc.to)
+    if ok != tc.ok || string(got) != tc.want {
+      t.Errorf("SomePath(%d, %d) = (%q, %v), want (%q, %v)", tc.from, tc.to, got, ok, tc.want, tc.ok)
+    }
+  }
+}
+
+func TestGraphAllPaths(t *testing.T) {
+  g := New(fixedEdges)
+
+  toStrings := func(paths [][]byte) []string {
+    strs := make([]string, len(paths))
+    for i, p := range paths {
+      strs[i] = string(p)
+    }
+    sort.Strings(strs)
+    return strs
+  }
+
+  if got, want := toStrings(g.AllPaths(0, 2)), []string{"a", "bz"}; !reflect.DeepEqual(got, want) {
+    t.Errorf("AllPaths(0, 2) = %v, want %v", got, want)
+  }
+  if got, want := toStrings(g.AllPaths(0, 3)), []string{"b"}; !reflect.DeepEqual(got, want) {
+    t.Errorf("AllPaths(0, 3) = %v, want %v", got, want)
+  }
+}
+
+func TestGraphForwardReverse(t *testing.T) {
+  g := New(fixedEdges)
+
+  if got, want := sortedInts(g.Forward(3)), []int{2, 3}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Forward(3) = %v, want %v", got, want)
+  }
+  if got, want := sortedInts(g.Reverse(2)), []int{0, 2, 3}; !reflect.DeepEqual(got, want) {
+    t.Errorf("Reverse(2) = %v, want %v", got, want)
+  }
+}
@@ -0,0 +1,92 @@
+package dawg
+
+// ReadOnlyDAWG is a DAWG decoded from the compact binary format produced by
+// WriteBinary. It exposes the same Lookup/LookupPrefix API as DAWG but
+// never reconstructs a *Node: edges are decoded on the fly out of the
+// underlying byte slice, which may be mmap'd and shared read-only across
+// processes.
+type ReadOnlyDAWG struct {
+  data      []byte
+  edgeCount int
+  indexBits uint
+  edgeBits  uint
+}
+
+type roEdge struct {
+  label byte
+  final bool
+  eol   bool
+  index int
+}
+
+func (r *ReadOnlyDAWG) edge(i int) roEdge {
+  v := readBits(r.data, uint64(i)*uint64(r.edgeBits), r.edgeBits)
+
+  index := int(v & ((1 << r.indexBits) - 1))
+  v >>= r.indexBits
+  eol := v&1 != 0
+  v >>= 1
+  final := v&1 != 0
+  v >>= 1
+  label := byte(v)
+
+  return roEdge{label: label, final: final, eol: eol, index: index}
+}
+
+// findEdge scans the edge group starting at group for an edge labelled b.
+func (r *ReadOnlyDAWG) findEdge(group int, b byte) (roEdge, bool) {
+  for i := group; i < r.edgeCount; i++ {
+    e := r.edge(i)
+    if e.label == b {
+      return e, true
+    }
+    if e.eol {
+      break
+    }
+  }
+  return roEdge{}, false
+}
+
+// Lookup searches for word in the graph. It returns true iff the graph
+// contains the word.
+func (r *ReadOnlyDAWG) Lookup(word string) bool {
+  group := 0
+  for i := 0; i < len(word); i++ {
+    e, ok := r.findEdge(group, word[i])
+    if !ok {
+      return false
+    }
+    if i == len(word)-1 {
+      return e.final
+    }
+    if e.index == 0 {
+      return false
+    }
+    group = e.index
+  }
+  return false
+}
+
+// LookupPrefix searches for a word with the given prefix in the graph.
+// LookupPrefix returns the rest of one word that begins with prefix: the
+// labels read after prefix along the first edge of each group until a
+// final node is reached. If prefix is not in the graph it returns an empty
+// string. The second return value indicates success.
+func (r *ReadOnlyDAWG) LookupPrefix(prefix string) (string, bool) {
+  group, final := 0, false
+  for i := 0; i < len(prefix); i++ {
+    e, ok := r.findEdge(group, prefix[i])
+    if !ok {
+      return "", false
+    }
+    group, final = e.index, e.final
+  }
+
+  var buf []byte
+  for !final {
+    e := r.edge(group)
+    buf = append(buf, e.label)
+    group, final = e.index, e.final
+  }
+  return string(buf), true
+}